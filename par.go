@@ -0,0 +1,220 @@
+package ik
+
+import (
+	"iter"
+	"sync"
+)
+
+// Pool is a fixed-size set of worker goroutines that can run many
+// pipelines without recreating goroutines for every pipeline (ParMap,
+// ParFilter, and ParForEach each create and close a Pool of their own).
+// A Pool's workers stay alive until Close is called, so
+// the same Pool can be reused across many stages via repeated calls to
+// Process or ProcessUnordered.
+type Pool[T, U any] struct {
+	tasks chan func()
+	size  int
+	wg    sync.WaitGroup
+}
+
+// NewPool creates a Pool with the given number of workers.
+func NewPool[T, U any](workers int) *Pool[T, U] {
+	if workers < 1 {
+		panic("Pool size too small")
+	}
+
+	p := &Pool[T, U]{
+		tasks: make(chan func(), workers),
+		size:  workers,
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer p.wg.Done()
+			for task := range p.tasks {
+				task()
+			}
+		}()
+	}
+
+	return p
+}
+
+// Submit schedules fn to run on the pool, blocking until the pool's task
+// queue (buffered to the number of workers) accepts it or done is closed.
+// It reports whether fn was accepted.
+func (p *Pool[T, U]) Submit(fn func(), done <-chan struct{}) bool {
+	select {
+	case p.tasks <- fn:
+		return true
+	case <-done:
+		return false
+	}
+}
+
+// Close stops accepting new work and waits for in-flight tasks to finish.
+// The Pool must not be used after Close.
+func (p *Pool[T, U]) Close() {
+	close(p.tasks)
+	p.wg.Wait()
+}
+
+// poolOutcome carries either a mapped value or a recovered panic back to
+// the consumer of a Process/ProcessUnordered pipeline.
+type poolOutcome[U any] struct {
+	value U
+	pan   any
+}
+
+// Process runs mapper over s across the pool's workers, preserving
+// source order. Feeding the pool stops as soon as the downstream yield
+// returns false, and a panic raised by mapper is propagated to the
+// consumer of the returned iter.Seq.
+func (p *Pool[T, U]) Process(s iter.Seq[T], mapper func(T) U) iter.Seq[U] {
+	return p.process(s, mapper, true)
+}
+
+// ProcessUnordered is like Process, but yields each result as soon as
+// it's ready instead of preserving source order.
+func (p *Pool[T, U]) ProcessUnordered(s iter.Seq[T], mapper func(T) U) iter.Seq[U] {
+	return p.process(s, mapper, false)
+}
+
+func (p *Pool[T, U]) process(s iter.Seq[T], mapper func(T) U, ordered bool) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		done := make(chan struct{})
+		var stopOnce sync.Once
+		stop := func() { stopOnce.Do(func() { close(done) }) }
+		defer stop()
+
+		ordering := make(chan chan poolOutcome[U], p.size)
+		unordered := make(chan poolOutcome[U], p.size)
+
+		run := func(t T, out func(poolOutcome[U])) func() {
+			return func() {
+				defer func() {
+					if r := recover(); r != nil {
+						out(poolOutcome[U]{pan: r})
+					}
+				}()
+				out(poolOutcome[U]{value: mapper(t)})
+			}
+		}
+
+		var feedWG, tasksWG sync.WaitGroup
+		feedWG.Add(1)
+		go func() {
+			defer feedWG.Done()
+			if ordered {
+				defer close(ordering)
+			}
+
+			s(func(t T) bool {
+				if ordered {
+					out := make(chan poolOutcome[U], 1)
+					select {
+					case ordering <- out:
+					case <-done:
+						return false
+					}
+					return p.Submit(run(t, func(o poolOutcome[U]) { out <- o }), done)
+				}
+
+				tasksWG.Add(1)
+				submitted := p.Submit(run(t, func(o poolOutcome[U]) {
+					defer tasksWG.Done()
+					select {
+					case unordered <- o:
+					case <-done:
+					}
+				}), done)
+				if !submitted {
+					tasksWG.Done()
+				}
+				return submitted
+			})
+		}()
+
+		if !ordered {
+			go func() {
+				feedWG.Wait()
+				tasksWG.Wait()
+				close(unordered)
+			}()
+		}
+
+		consume := func(o poolOutcome[U]) bool {
+			if o.pan != nil {
+				stop()
+				feedWG.Wait()
+				panic(o.pan)
+			}
+			return yield(o.value)
+		}
+
+		if ordered {
+			for out := range ordering {
+				if !consume(<-out) {
+					stop()
+					break
+				}
+			}
+		} else {
+			for o := range unordered {
+				if !consume(o) {
+					stop()
+					break
+				}
+			}
+		}
+
+		feedWG.Wait()
+	}
+}
+
+// ParMap runs mapper over s across a fixed pool of workers goroutines,
+// preserving the source order of the results.
+func ParMap[T, U any](s iter.Seq[T], workers int, mapper func(T) U) iter.Seq[U] {
+	pool := NewPool[T, U](workers)
+	return func(yield func(U) bool) {
+		defer pool.Close()
+		pool.Process(s, mapper)(yield)
+	}
+}
+
+// ParMapUnordered is like ParMap, but yields each mapped value as soon as
+// it's ready instead of preserving source order. Prefer this over ParMap
+// when only throughput matters.
+func ParMapUnordered[T, U any](s iter.Seq[T], workers int, mapper func(T) U) iter.Seq[U] {
+	pool := NewPool[T, U](workers)
+	return func(yield func(U) bool) {
+		defer pool.Close()
+		pool.ProcessUnordered(s, mapper)(yield)
+	}
+}
+
+// ParFilter is Filter, but filter is run across a fixed pool of workers
+// goroutines.
+func ParFilter[T any](s iter.Seq[T], workers int, filter func(T) bool) iter.Seq[T] {
+	type tagged struct {
+		t    T
+		keep bool
+	}
+
+	tagged2 := ParMap(s, workers, func(t T) tagged {
+		return tagged{t: t, keep: filter(t)}
+	})
+
+	return Map(Filter(tagged2, func(tg tagged) bool { return tg.keep }), func(tg tagged) T { return tg.t })
+}
+
+// ParForEach runs f over each value in s across a fixed pool of workers
+// goroutines, stopping early if f returns false.
+func ParForEach[T any](s iter.Seq[T], workers int, f func(T) bool) {
+	for cont := range ParMap(s, workers, f) {
+		if !cont {
+			return
+		}
+	}
+}
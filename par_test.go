@@ -0,0 +1,135 @@
+package ik
+
+import (
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestParMapOrder(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	got := Collect(ParMap(slices.Values(in), 4, func(i int) int { return i * i }))
+	want := []int{1, 4, 9, 16, 25, 36, 49, 64}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ParMap = %v, want %v", got, want)
+	}
+}
+
+func TestParMapEarlyTermination(t *testing.T) {
+	in := slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	got := Collect(Take(ParMap(in, 3, func(i int) int { return i * 2 }), 3))
+	want := []int{2, 4, 6}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ParMap early termination = %v, want %v", got, want)
+	}
+}
+
+func TestParMapUnorderedEarlyTermination(t *testing.T) {
+	in := slices.Values([]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+	got := Collect(Take(ParMapUnordered(in, 3, func(i int) int { return i * 2 }), 3))
+	if len(got) != 3 {
+		t.Fatalf("ParMapUnordered early termination yielded %d values, want 3", len(got))
+	}
+}
+
+func TestParMapPanicPropagation(t *testing.T) {
+	defer func() {
+		r := recover()
+		if r == nil {
+			t.Fatal("expected ParMap to propagate a worker panic, got none")
+		}
+		if r != "boom" {
+			t.Fatalf("recovered %v, want boom", r)
+		}
+	}()
+
+	Collect(ParMap(slices.Values([]int{1, 2, 3}), 2, func(i int) int {
+		if i == 2 {
+			panic("boom")
+		}
+		return i
+	}))
+}
+
+func TestParMapUnorderedPanicPropagation(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected ParMapUnordered to propagate a worker panic, got none")
+		}
+	}()
+
+	Collect(ParMapUnordered(slices.Values([]int{1, 2, 3}), 2, func(i int) int {
+		if i == 2 {
+			panic("boom")
+		}
+		return i
+	}))
+}
+
+func TestPoolReuse(t *testing.T) {
+	pool := NewPool[int, int](3)
+	defer pool.Close()
+
+	for i := range 5 {
+		got := Collect(pool.Process(slices.Values([]int{1, 2, 3}), func(n int) int { return n + i }))
+		want := []int{1 + i, 2 + i, 3 + i}
+		if !slices.Equal(got, want) {
+			t.Fatalf("pass %d: pool.Process = %v, want %v", i, got, want)
+		}
+	}
+}
+
+func TestParFilter(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6}
+	got := Collect(ParFilter(slices.Values(in), 3, func(i int) bool { return i%2 == 0 }))
+	want := []int{2, 4, 6}
+	if !slices.Equal(got, want) {
+		t.Fatalf("ParFilter = %v, want %v", got, want)
+	}
+}
+
+func TestParForEach(t *testing.T) {
+	var sum atomic.Int64
+	ParForEach(slices.Values([]int{1, 2, 3, 4, 5}), 3, func(i int) bool {
+		sum.Add(int64(i))
+		return true
+	})
+	if sum.Load() != 15 {
+		t.Fatalf("ParForEach sum = %d, want 15", sum.Load())
+	}
+}
+
+// cpuBoundSquare is a deliberately CPU-heavy mapper used to give ParMap
+// something worth parallelizing in BenchmarkParMap.
+func cpuBoundSquare(i int) int {
+	x := i
+	for j := 0; j < 1000; j++ {
+		x = (x*31 + j) % 1000003
+	}
+	return x
+}
+
+func BenchmarkMap(b *testing.B) {
+	in := make([]int, 1000)
+	for i := range in {
+		in[i] = i
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		Collect(Map(slices.Values(in), cpuBoundSquare))
+	}
+}
+
+func BenchmarkParMap(b *testing.B) {
+	in := make([]int, 1000)
+	for i := range in {
+		in[i] = i
+	}
+
+	b.ResetTimer()
+	for range b.N {
+		Collect(ParMap(slices.Values(in), runtime.NumCPU(), cpuBoundSquare))
+	}
+}
@@ -0,0 +1,109 @@
+package ik
+
+import "iter"
+
+// Map2 maps s to another Seq2 using mapper.
+func Map2[K, V, K2, V2 any](s iter.Seq2[K, V], mapper func(K, V) (K2, V2)) iter.Seq2[K2, V2] {
+	return func(yield func(K2, V2) bool) {
+		s(func(k K, v V) bool {
+			k2, v2 := mapper(k, v)
+			return yield(k2, v2)
+		})
+	}
+}
+
+// Filter2 filters out unwanted pairs from s using filter.
+func Filter2[K, V any](s iter.Seq2[K, V], filter func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		s(func(k K, v V) bool {
+			if filter(k, v) {
+				return yield(k, v)
+			}
+
+			return true
+		})
+	}
+}
+
+// Reduce2 reduces the pairs found in s using reduce and init.
+func Reduce2[K, V, U any](s iter.Seq2[K, V], reduce func(k K, v V, u U) U, init U) U {
+	for k, v := range s {
+		init = reduce(k, v, init)
+	}
+	return init
+}
+
+// Collect2 collects s into a slice of Pair.
+func Collect2[K, V any](s iter.Seq2[K, V]) []Pair[K, V] {
+	return Collect(Seq2Seq(s))
+}
+
+// CollectMap collects s into a map.
+func CollectMap[K comparable, V any](s iter.Seq2[K, V]) map[K]V {
+	m := make(map[K]V)
+	s(func(k K, v V) bool {
+		m[k] = v
+		return true
+	})
+	return m
+}
+
+// Keys yields the keys of s.
+func Keys[K, V any](s iter.Seq2[K, V]) iter.Seq[K] {
+	return func(yield func(K) bool) {
+		s(func(k K, v V) bool {
+			return yield(k)
+		})
+	}
+}
+
+// Vals yields the values of s.
+func Vals[K, V any](s iter.Seq2[K, V]) iter.Seq[V] {
+	return func(yield func(V) bool) {
+		s(func(k K, v V) bool {
+			return yield(v)
+		})
+	}
+}
+
+// Zip combines a and b into a Seq2, stopping as soon as either is
+// exhausted.
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq2[A, B] {
+	return func(yield func(A, B) bool) {
+		next, stop := iter.Pull(b)
+		defer stop()
+
+		a(func(av A) bool {
+			bv, ok := next()
+			if !ok {
+				return false
+			}
+
+			return yield(av, bv)
+		})
+	}
+}
+
+// Enumerate2 pairs each (k, v) in s with its index, mirroring Enumerate
+// for Seq2.
+func Enumerate2[K, V any](s iter.Seq2[K, V]) iter.Seq2[int, Pair[K, V]] {
+	return func(yield func(int, Pair[K, V]) bool) {
+		idx := 0
+		s(func(k K, v V) bool {
+			ret := yield(idx, Pair[K, V]{K: k, V: v})
+			idx++
+			return ret
+		})
+	}
+}
+
+// Backward yields the values in s in reverse order.
+func Backward[T any](s []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s) - 1; i >= 0; i-- {
+			if !yield(s[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package ik
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"iter"
+)
+
+// writeCsvFlushEvery is how often WriteCsv flushes its underlying
+// csv.Writer.
+const writeCsvFlushEvery = 100
+
+// noErr adapts s into a Seq2 that never produces an error, so a single
+// Err-suffixed implementation can serve both the plain and error-aware
+// entry points in this file.
+func noErr[T any](s iter.Seq[T]) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		s(func(t T) bool {
+			return yield(t, nil)
+		})
+	}
+}
+
+// WriteCsv writes the rows in s to w as CSV, flushing periodically so
+// long streams don't buffer entirely in memory. It stops and returns the
+// error as soon as writing a row fails. For a source which can itself
+// produce errors (e.g. Csv reading back what it wrote), use WriteCsvErr
+// instead so that a source error stops the write and is returned too.
+func WriteCsv(w io.Writer, s iter.Seq[[]string]) error {
+	return WriteCsvErr(w, noErr(s))
+}
+
+// WriteCsvErr is WriteCsv for a Seq2[[]string, error] source: it stops
+// and returns the first error produced by either s or the underlying
+// csv.Writer.
+func WriteCsvErr(w io.Writer, s iter.Seq2[[]string, error]) error {
+	cw := csv.NewWriter(w)
+
+	var retErr error
+	i := 0
+	s(func(row []string, err error) bool {
+		if err != nil {
+			retErr = err
+			return false
+		}
+
+		if err := cw.Write(row); err != nil {
+			retErr = err
+			return false
+		}
+
+		i++
+		if i%writeCsvFlushEvery == 0 {
+			cw.Flush()
+			if err := cw.Error(); err != nil {
+				retErr = err
+				return false
+			}
+		}
+
+		return true
+	})
+
+	if retErr != nil {
+		return retErr
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJsonArray writes the values in s to w as a single streamed JSON
+// array, encoding each value as it arrives instead of materializing the
+// whole slice first. For a source which can itself produce errors (e.g.
+// JsonOf or SqlOf), use WriteJsonArrayErr instead so that a source error
+// stops the write and is returned too.
+func WriteJsonArray[T any](w io.Writer, s iter.Seq[T]) error {
+	return WriteJsonArrayErr(w, noErr(s))
+}
+
+// WriteJsonArrayErr is WriteJsonArray for a Seq2[T, error] source: it
+// stops and returns the first error produced by either s or the
+// underlying json.Encoder.
+func WriteJsonArrayErr[T any](w io.Writer, s iter.Seq2[T, error]) error {
+	if _, err := io.WriteString(w, "["); err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	first := true
+	var retErr error
+	s(func(t T, err error) bool {
+		if err != nil {
+			retErr = err
+			return false
+		}
+
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				retErr = err
+				return false
+			}
+		}
+		first = false
+
+		if err := enc.Encode(t); err != nil {
+			retErr = err
+			return false
+		}
+
+		return true
+	})
+
+	if retErr != nil {
+		return retErr
+	}
+
+	_, err := io.WriteString(w, "]")
+	return err
+}
+
+// WriteJsonLines writes the values in s to w as newline-delimited JSON.
+// For a source which can itself produce errors (e.g. JsonOf or SqlOf),
+// use WriteJsonLinesErr instead so that a source error stops the write
+// and is returned too.
+func WriteJsonLines[T any](w io.Writer, s iter.Seq[T]) error {
+	return WriteJsonLinesErr(w, noErr(s))
+}
+
+// WriteJsonLinesErr is WriteJsonLines for a Seq2[T, error] source: it
+// stops and returns the first error produced by either s or the
+// underlying json.Encoder.
+func WriteJsonLinesErr[T any](w io.Writer, s iter.Seq2[T, error]) error {
+	enc := json.NewEncoder(w)
+
+	var retErr error
+	s(func(t T, err error) bool {
+		if err != nil {
+			retErr = err
+			return false
+		}
+
+		if err := enc.Encode(t); err != nil {
+			retErr = err
+			return false
+		}
+
+		return true
+	})
+
+	return retErr
+}
+
+// insertConfig holds InsertSql's options.
+type insertConfig struct {
+	batchSize int
+}
+
+// InsertOption configures InsertSql.
+type InsertOption func(*insertConfig)
+
+// WithBatchSize batches n rows per transaction instead of committing a
+// transaction after every row.
+func WithBatchSize(n int) InsertOption {
+	return func(c *insertConfig) {
+		c.batchSize = n
+	}
+}
+
+// InsertSql inserts the values in s into db using query, reusing a
+// single prepared statement across every row. By default each row is
+// committed in its own transaction; pass WithBatchSize to batch n rows
+// per transaction instead. It stops and returns early if binding,
+// executing, or committing a row fails. For a source which can itself
+// produce errors (e.g. SqlOf), use InsertSqlErr instead so that a source
+// error stops the insert and is returned too.
+func InsertSql[T any](db *sql.DB, query string, s iter.Seq[T], bind func(T) []any, opts ...InsertOption) error {
+	return InsertSqlErr(db, query, noErr(s), bind, opts...)
+}
+
+// InsertSqlErr is InsertSql for a Seq2[T, error] source: it stops and
+// returns the first error produced by s, the bound exec, or the commit.
+func InsertSqlErr[T any](db *sql.DB, query string, s iter.Seq2[T, error], bind func(T) []any, opts ...InsertOption) error {
+	cfg := insertConfig{batchSize: 1}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	stmt, err := db.Prepare(query)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	var tx *sql.Tx
+	var txStmt *sql.Stmt
+	count := 0
+
+	flush := func() error {
+		if tx == nil {
+			return nil
+		}
+
+		err := tx.Commit()
+		tx, txStmt, count = nil, nil, 0
+		return err
+	}
+
+	var retErr error
+	s(func(t T, srcErr error) bool {
+		if srcErr != nil {
+			retErr = srcErr
+			return false
+		}
+
+		if tx == nil {
+			tx, retErr = db.Begin()
+			if retErr != nil {
+				return false
+			}
+			txStmt = tx.Stmt(stmt)
+		}
+
+		if _, err := txStmt.Exec(bind(t)...); err != nil {
+			tx.Rollback()
+			tx, txStmt, count = nil, nil, 0
+			retErr = err
+			return false
+		}
+
+		count++
+		if count >= cfg.batchSize {
+			if err := flush(); err != nil {
+				retErr = err
+				return false
+			}
+		}
+
+		return true
+	})
+
+	if retErr != nil {
+		return retErr
+	}
+
+	return flush()
+}
@@ -0,0 +1,182 @@
+package ik
+
+import "iter"
+
+// TryMap maps s to another type using mapper, passing errors from s
+// through untouched and short-circuiting as soon as mapper returns one.
+func TryMap[T, U any](s iter.Seq2[T, error], mapper func(T) (U, error)) iter.Seq2[U, error] {
+	return func(yield func(U, error) bool) {
+		s(func(t T, err error) bool {
+			if err != nil {
+				var zero U
+				return yield(zero, err)
+			}
+
+			u, err := mapper(t)
+			if err != nil {
+				yield(u, err)
+				return false
+			}
+
+			return yield(u, nil)
+		})
+	}
+}
+
+// TryFilter filters out unwanted values from s using filter, passing
+// errors from s or filter through untouched.
+func TryFilter[T any](s iter.Seq2[T, error], filter func(T) (bool, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		s(func(t T, err error) bool {
+			if err != nil {
+				return yield(t, err)
+			}
+
+			ok, err := filter(t)
+			if err != nil {
+				var zero T
+				return yield(zero, err)
+			}
+
+			if ok {
+				return yield(t, nil)
+			}
+
+			return true
+		})
+	}
+}
+
+// TryReduce reduces the values found in s using reduce and init,
+// stopping at the first error.
+func TryReduce[T, U any](s iter.Seq2[T, error], reduce func(t T, u U) U, init U) (U, error) {
+	var retErr error
+	s(func(t T, err error) bool {
+		if err != nil {
+			retErr = err
+			return false
+		}
+
+		init = reduce(t, init)
+		return true
+	})
+
+	return init, retErr
+}
+
+// TryChunk processes s in chunks of size chunkSize, stopping at the
+// first error.
+func TryChunk[T any](s iter.Seq2[T, error], chunkSize int) iter.Seq2[[]T, error] {
+	if chunkSize < 1 {
+		panic("Chunk size too small")
+	}
+
+	return func(yield func([]T, error) bool) {
+		chunk := make([]T, chunkSize)
+		idx := 0
+		stopped := false
+		s(func(t T, err error) bool {
+			if err != nil {
+				stopped = true
+				yield(nil, err)
+				return false
+			}
+
+			chunk[idx] = t
+			idx++
+			if idx == chunkSize {
+				idx = 0
+				return yield(chunk, nil)
+			}
+
+			return true
+		})
+
+		if idx != 0 && !stopped {
+			yield(chunk[:idx], nil)
+		}
+	}
+}
+
+// TryTake takes the first n values from s, stopping early on error.
+func TryTake[T any](s iter.Seq2[T, error], n int) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		i := 0
+		s(func(t T, err error) bool {
+			if err != nil {
+				yield(t, err)
+				return false
+			}
+
+			i++
+			if i <= n {
+				return yield(t, nil)
+			}
+
+			return false
+		})
+	}
+}
+
+// TryFirst returns the first value in s which matches predicate, or the
+// first error encountered, whichever comes first.
+func TryFirst[T any](s iter.Seq2[T, error], predicate func(T) bool) (t T, err error, ok bool) {
+	s(func(iterT T, iterErr error) bool {
+		if iterErr != nil {
+			err = iterErr
+			return false
+		}
+
+		if predicate(iterT) {
+			t = iterT
+			ok = true
+			return false
+		}
+
+		return true
+	})
+
+	return
+}
+
+// CollectErr collects s into a slice, short-circuiting on the first
+// error.
+func CollectErr[T any](s iter.Seq2[T, error]) ([]T, error) {
+	var ret []T
+	var retErr error
+	s(func(t T, err error) bool {
+		if err != nil {
+			retErr = err
+			return false
+		}
+
+		ret = append(ret, t)
+		return true
+	})
+
+	return ret, retErr
+}
+
+// Catch lets handler decide whether to continue iterating s after an
+// error; returning false from handler stops iteration. Values of s which
+// carry no error are passed through untouched.
+func Catch[T any](s iter.Seq2[T, error], handler func(error) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s(func(t T, err error) bool {
+			if err != nil {
+				return handler(err)
+			}
+
+			return yield(t)
+		})
+	}
+}
+
+// MustElide is Elide under a name that makes the panicking behavior
+// unmistakable at the call site. Elide itself is kept, unchanged, for
+// backwards compatibility with existing callers; new code should prefer
+// MustElide, or better, CollectErr/Catch/the other Try* combinators when
+// errors should be handled rather than treated as fatal.
+func MustElide[T any](s iter.Seq2[T, error]) iter.Seq[T] {
+	return Elide(s)
+}
@@ -0,0 +1,215 @@
+package ik
+
+import (
+	"bufio"
+	"database/sql"
+	"encoding/json"
+	"io"
+	"iter"
+	"reflect"
+	"strings"
+)
+
+// SqlOf creates an iter.Seq2 from sql.Rows, scanning each row directly
+// into a T instead of requiring a hand-written Scan callback.
+//
+// If T is a struct, the column-to-field mapping is inspected once from
+// rows.Columns(), matching each column against a field's `db` tag, then
+// its `json` tag, then its name (case-insensitively) — recursing into
+// embedded structs so their fields are reachable directly, and skipping
+// unexported fields, which can't be addressed for Scan — and the
+// resulting field-index path is cached for the rest of the rows. Columns
+// with no matching field are discarded. If T is not a struct, each row
+// is scanned into a single value of T.
+//
+// Takes ownership of rows.
+func SqlOf[T any](rows *sql.Rows) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer rows.Close()
+
+		rowType := reflect.TypeFor[T]()
+		var fieldIdx [][]int
+		haveFieldIdx := false
+
+		for rows.Next() {
+			var t T
+
+			if rowType.Kind() == reflect.Struct {
+				if !haveFieldIdx {
+					cols, err := rows.Columns()
+					if err != nil {
+						if !yield(t, err) {
+							return
+						}
+						continue
+					}
+
+					fieldIdx = structFieldIndex(rowType, cols)
+					haveFieldIdx = true
+				}
+
+				v := reflect.ValueOf(&t).Elem()
+				dests := make([]any, len(fieldIdx))
+				for i, path := range fieldIdx {
+					if path == nil {
+						var discard any
+						dests[i] = &discard
+						continue
+					}
+
+					dests[i] = v.FieldByIndex(path).Addr().Interface()
+				}
+
+				if !yield(t, rows.Scan(dests...)) {
+					return
+				}
+			} else {
+				if !yield(t, rows.Scan(&t)) {
+					return
+				}
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			yield(*new(T), err)
+		}
+	}
+}
+
+// structFieldIndex builds, for each column name, the field-index path
+// (as consumed by reflect.Value.FieldByIndex) of the struct field it
+// should be scanned into; nil means the column has no matching field
+// and should be discarded.
+func structFieldIndex(t reflect.Type, cols []string) [][]int {
+	byName := map[string][]int{}
+	indexStructFields(t, nil, byName)
+
+	idx := make([][]int, len(cols))
+	for i, col := range cols {
+		idx[i] = byName[strings.ToLower(col)]
+	}
+
+	return idx
+}
+
+// indexStructFields walks t's fields, recording each named field's
+// index path (prefix plus its own index) in byName under its db tag,
+// then its json tag, then its field name, all lower-cased. Anonymous
+// struct fields are recursed into so their fields are reachable
+// directly; unexported fields are skipped entirely, since they can't be
+// addressed for Scan.
+func indexStructFields(t reflect.Type, prefix []int, byName map[string][]int) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		path := append(append([]int{}, prefix...), i)
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			indexStructFields(f.Type, path, byName)
+			continue
+		}
+
+		name := f.Tag.Get("db")
+		if name == "" {
+			name = f.Tag.Get("json")
+			if comma := strings.IndexByte(name, ','); comma >= 0 {
+				name = name[:comma]
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+
+		key := strings.ToLower(name)
+		if _, exists := byName[key]; !exists {
+			byName[key] = path
+		}
+	}
+}
+
+// JsonOf creates an iter.Seq2 of T values decoded from r.
+//
+// The shape of the stream is auto-detected by peeking its first
+// non-whitespace byte: if it begins a JSON array, each element of the
+// array is decoded into its own T; otherwise, Decode is called
+// repeatedly at the top level, which also supports a stream of
+// back-to-back JSON values.
+//
+// Takes ownership of r.
+func JsonOf[T any](r io.ReadCloser) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		defer r.Close()
+
+		br := bufio.NewReader(r)
+		isArray, err := peekArray(br)
+		if err != nil {
+			if err != io.EOF {
+				var zero T
+				yield(zero, err)
+			}
+			return
+		}
+
+		dec := json.NewDecoder(br)
+
+		if isArray {
+			if _, err := dec.Token(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for dec.More() {
+				var t T
+				if err := dec.Decode(&t); err != nil {
+					yield(t, err)
+					return
+				}
+
+				if !yield(t, nil) {
+					return
+				}
+			}
+
+			return
+		}
+
+		for {
+			var t T
+			err := dec.Decode(&t)
+			if err == io.EOF {
+				return
+			}
+
+			if err != nil {
+				yield(t, err)
+				return
+			}
+
+			if !yield(t, nil) {
+				return
+			}
+		}
+	}
+}
+
+// peekArray reports whether the next non-whitespace byte in br begins a
+// JSON array, without consuming anything from br.
+func peekArray(br *bufio.Reader) (bool, error) {
+	for i := 0; ; i++ {
+		b, err := br.Peek(i + 1)
+		if err != nil {
+			return false, err
+		}
+
+		switch b[i] {
+		case ' ', '\t', '\n', '\r':
+			continue
+		default:
+			return b[i] == '[', nil
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package ik
+
+import (
+	"iter"
+	"sync"
+)
+
+// Window yields overlapping windows of size elements from s, sliding
+// forward by step each time (step == size gives Chunk's non-overlapping
+// groups, without Chunk's trailing partial chunk). It is
+// backed by a single fixed-size ring buffer, allocated once up front and
+// reused for the rest of the stream; only the small output slice handed
+// to yield is allocated per emission, since the caller may retain it. s
+// stopping mid-window, or the downstream yield returning false, simply
+// ends iteration; only full windows are emitted, never a trailing
+// partial one.
+func Window[T any](s iter.Seq[T], size, step int) iter.Seq[[]T] {
+	if size < 1 {
+		panic("Window size too small")
+	}
+	if step < 1 {
+		panic("Window step too small")
+	}
+
+	return func(yield func([]T) bool) {
+		ring := make([]T, size)
+		pos := 0 // total number of elements written into ring so far
+
+		s(func(t T) bool {
+			ring[pos%size] = t
+			pos++
+
+			if pos >= size && (pos-size)%step == 0 {
+				window := make([]T, size)
+				for j := 0; j < size; j++ {
+					window[j] = ring[(pos-size+j)%size]
+				}
+
+				return yield(window)
+			}
+
+			return true
+		})
+	}
+}
+
+// Partition splits s into matched and unmatched streams based on pred.
+// s is only iterated once, and the channels connecting the two returned
+// Seqs to it are unbuffered, so whenever s produces a value for the other
+// stream, advancing one requires a consumer of the other to be actively
+// receiving at the same time. Drain both concurrently: ranging over (or
+// Collecting) one to completion before touching the other blocks as soon
+// as s produces a value for the other. Nothing is buffered. Stopping early on
+// either returned Seq tears down the shared producer goroutine instead
+// of leaking it.
+func Partition[T any](s iter.Seq[T], pred func(T) bool) (matched iter.Seq[T], unmatched iter.Seq[T]) {
+	matchedCh := make(chan T)
+	unmatchedCh := make(chan T)
+	done := make(chan struct{})
+
+	var startOnce sync.Once
+	start := func() {
+		startOnce.Do(func() {
+			go func() {
+				defer close(matchedCh)
+				defer close(unmatchedCh)
+				s(func(t T) bool {
+					out := matchedCh
+					if !pred(t) {
+						out = unmatchedCh
+					}
+
+					select {
+					case out <- t:
+						return true
+					case <-done:
+						return false
+					}
+				})
+			}()
+		})
+	}
+
+	var stopOnce sync.Once
+	stop := func() { stopOnce.Do(func() { close(done) }) }
+
+	matched = func(yield func(T) bool) {
+		start()
+		for t := range matchedCh {
+			if !yield(t) {
+				stop()
+				return
+			}
+		}
+	}
+
+	unmatched = func(yield func(T) bool) {
+		start()
+		for t := range unmatchedCh {
+			if !yield(t) {
+				stop()
+				return
+			}
+		}
+	}
+
+	return matched, unmatched
+}
+
+// GroupBy yields runs of consecutive values from s which share a key, as
+// computed by key. It is streaming, not a full group-by: two runs with
+// the same key separated by a different key in between are yielded as
+// separate groups.
+func GroupBy[T any, K comparable](s iter.Seq[T], key func(T) K) iter.Seq2[K, []T] {
+	return func(yield func(K, []T) bool) {
+		var cur []T
+		var curKey K
+		haveKey := false
+		stopped := false
+
+		s(func(t T) bool {
+			k := key(t)
+			if haveKey && k == curKey {
+				cur = append(cur, t)
+				return true
+			}
+
+			if haveKey && !yield(curKey, cur) {
+				stopped = true
+				return false
+			}
+
+			curKey = k
+			cur = []T{t}
+			haveKey = true
+			return true
+		})
+
+		if haveKey && !stopped {
+			yield(curKey, cur)
+		}
+	}
+}
+
+// FlatMap maps each value in s to a Seq using f, then flattens the
+// results into a single Seq.
+func FlatMap[T, U any](s iter.Seq[T], f func(T) iter.Seq[U]) iter.Seq[U] {
+	return func(yield func(U) bool) {
+		s(func(t T) bool {
+			cont := true
+			f(t)(func(u U) bool {
+				cont = yield(u)
+				return cont
+			})
+			return cont
+		})
+	}
+}
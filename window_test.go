@@ -0,0 +1,181 @@
+package ik
+
+import (
+	"iter"
+	"slices"
+	"testing"
+)
+
+func TestWindowOverlapping(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	got := Collect(Window(slices.Values(in), 3, 1))
+	want := [][]int{
+		{1, 2, 3}, {2, 3, 4}, {3, 4, 5}, {4, 5, 6},
+		{5, 6, 7}, {6, 7, 8}, {7, 8, 9},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Window = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("Window = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestWindowStepEqualsSize(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6}
+	got := Collect(Window(slices.Values(in), 2, 2))
+	want := [][]int{{1, 2}, {3, 4}, {5, 6}}
+	if len(got) != len(want) {
+		t.Fatalf("Window = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("Window = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestWindowStepGreaterThanSize(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8, 9}
+	got := Collect(Window(slices.Values(in), 2, 3))
+	want := [][]int{{1, 2}, {4, 5}, {7, 8}}
+	if len(got) != len(want) {
+		t.Fatalf("Window = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("Window = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestWindowEarlyTermination(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	got := Collect(Take(Window(slices.Values(in), 3, 1), 2))
+	want := [][]int{{1, 2, 3}, {2, 3, 4}}
+	if len(got) != len(want) {
+		t.Fatalf("Window early termination = %v, want %v", got, want)
+	}
+	for i := range want {
+		if !slices.Equal(got[i], want[i]) {
+			t.Fatalf("Window early termination = %v, want %v", got, want)
+		}
+	}
+
+	// A fresh, uninterrupted pass over the same input yields [3 4 5] as
+	// its third window, the one the stopped pass would have emitted next.
+	all := Collect(Window(slices.Values(in), 3, 1))
+	if !slices.Equal(all[2], []int{3, 4, 5}) {
+		t.Fatalf("Window after a would-be early stop = %v, want [3 4 5]", all[2])
+	}
+}
+
+func TestWindowOutputsAreIndependentSlices(t *testing.T) {
+	in := []int{1, 2, 3, 4}
+	got := Collect(Window(slices.Values(in), 2, 1))
+	if len(got) != 3 {
+		t.Fatalf("got %d windows, want 3", len(got))
+	}
+	if !slices.Equal(got[0], []int{1, 2}) {
+		t.Fatalf("first window mutated by later emissions: %v", got[0])
+	}
+}
+
+func TestPartitionConcurrentDrain(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6}
+	matched, unmatched := Partition(slices.Values(in), func(i int) bool { return i%2 == 0 })
+
+	var evens, odds []int
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		odds = Collect(unmatched)
+	}()
+	evens = Collect(matched)
+	<-done
+
+	slices.Sort(evens)
+	slices.Sort(odds)
+	if !slices.Equal(evens, []int{2, 4, 6}) {
+		t.Fatalf("evens = %v, want [2 4 6]", evens)
+	}
+	if !slices.Equal(odds, []int{1, 3, 5}) {
+		t.Fatalf("odds = %v, want [1 3 5]", odds)
+	}
+}
+
+func TestPartitionEarlyTerminationDoesNotHang(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	matched, unmatched := Partition(slices.Values(in), func(i int) bool { return i%2 == 0 })
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range unmatched {
+			// drain odds so the producer never blocks on them
+		}
+	}()
+
+	got := Collect(Take(matched, 1))
+	if !slices.Equal(got, []int{2}) {
+		t.Fatalf("matched = %v, want [2]", got)
+	}
+	<-done
+}
+
+func TestGroupBy(t *testing.T) {
+	in := []int{1, 1, 2, 2, 2, 1, 3}
+	groups := Collect2(GroupBy(slices.Values(in), func(i int) int { return i }))
+
+	if len(groups) != 4 {
+		t.Fatalf("GroupBy produced %d groups, want 4: %v", len(groups), groups)
+	}
+	if groups[0].K != 1 || !slices.Equal(groups[0].V, []int{1, 1}) {
+		t.Fatalf("group 0 = %v, want key 1 values [1 1]", groups[0])
+	}
+	if groups[1].K != 2 || !slices.Equal(groups[1].V, []int{2, 2, 2}) {
+		t.Fatalf("group 1 = %v, want key 2 values [2 2 2]", groups[1])
+	}
+	if groups[2].K != 1 || !slices.Equal(groups[2].V, []int{1}) {
+		t.Fatalf("group 2 = %v, want key 1 values [1]", groups[2])
+	}
+	if groups[3].K != 3 || !slices.Equal(groups[3].V, []int{3}) {
+		t.Fatalf("group 3 = %v, want key 3 values [3]", groups[3])
+	}
+}
+
+func TestGroupByEarlyTermination(t *testing.T) {
+	in := []int{1, 1, 2, 2, 3, 3}
+	var groups []Pair[int, []int]
+	GroupBy(slices.Values(in), func(i int) int { return i })(func(k int, v []int) bool {
+		groups = append(groups, Pair[int, []int]{K: k, V: v})
+		return false
+	})
+	if len(groups) != 1 || groups[0].K != 1 || !slices.Equal(groups[0].V, []int{1, 1}) {
+		t.Fatalf("GroupBy early termination = %v, want a single group keyed 1 with values [1 1]", groups)
+	}
+}
+
+func TestFlatMap(t *testing.T) {
+	in := []int{1, 2, 3}
+	got := Collect(FlatMap(slices.Values(in), func(i int) iter.Seq[int] {
+		return slices.Values([]int{i, i * 10})
+	}))
+	want := []int{1, 10, 2, 20, 3, 30}
+	if !slices.Equal(got, want) {
+		t.Fatalf("FlatMap = %v, want %v", got, want)
+	}
+}
+
+func TestFlatMapEarlyTermination(t *testing.T) {
+	in := []int{1, 2, 3}
+	got := Collect(Take(FlatMap(slices.Values(in), func(i int) iter.Seq[int] {
+		return slices.Values([]int{i, i * 10, i * 100})
+	}), 4))
+	want := []int{1, 10, 100, 2}
+	if !slices.Equal(got, want) {
+		t.Fatalf("FlatMap early termination = %v, want %v", got, want)
+	}
+}